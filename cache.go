@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// pageCacheVersion is bumped whenever the on-disk cache schema changes, so a
+// future version of docscrap can tell an old cache file apart from a
+// corrupt one.
+const pageCacheVersion = 1
+
+// cacheEntry is everything -incremental needs to remember about a
+// previously scraped page: enough to send conditional request headers next
+// time, detect a content change even when the server doesn't support
+// conditional requests, and rebuild that page's index.md entry without
+// re-fetching it.
+type cacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	ContentHash  string `json:"content_hash"`
+	LastFetched  string `json:"last_fetched"`
+
+	Title    string `json:"title"`
+	Filename string `json:"filename"`
+	Level    int    `json:"level"`
+
+	// Content, ContentText, Headings and CodeBlocks mirror the
+	// corresponding Page fields, so a 304/unchanged page can still be fed
+	// to aggregate emitters (JSONL, HTML, EPUB, PDF, single-file Markdown)
+	// in full instead of dropping out of them on a re-run.
+	Content     string      `json:"content"`
+	ContentText string      `json:"content_text"`
+	Headings    []Heading   `json:"headings,omitempty"`
+	CodeBlocks  []CodeBlock `json:"code_blocks,omitempty"`
+}
+
+// pageCache is the sidecar `.docscrap-cache.json` file used by -incremental
+// runs.
+type pageCache struct {
+	Version int                   `json:"version"`
+	Entries map[string]cacheEntry `json:"entries"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// loadPageCache reads path if it exists, tolerating a missing or corrupt
+// file by starting from an empty cache rather than failing the run -
+// incremental mode should degrade to "scrape everything" at worst, never
+// block a crawl.
+func loadPageCache(path string) *pageCache {
+	cache := &pageCache{Version: pageCacheVersion, Entries: make(map[string]cacheEntry), path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+
+	var onDisk pageCache
+	if err := json.Unmarshal(data, &onDisk); err != nil {
+		log.Printf("incremental cache %s is unreadable (%v); starting fresh", path, err)
+		return cache
+	}
+
+	if onDisk.Version != pageCacheVersion {
+		log.Printf("incremental cache %s is schema version %d (expected %d); starting fresh", path, onDisk.Version, pageCacheVersion)
+		return cache
+	}
+
+	if onDisk.Entries == nil {
+		onDisk.Entries = make(map[string]cacheEntry)
+	}
+	onDisk.path = path
+	return &onDisk
+}
+
+func (c *pageCache) get(url string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.Entries[url]
+	return entry, ok
+}
+
+func (c *pageCache) set(url string, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Entries[url] = entry
+}
+
+func (c *pageCache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Version = pageCacheVersion
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0644)
+}
+
+func hashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func nowStamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
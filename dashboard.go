@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync/atomic"
+)
+
+// Counters tracks crawl progress so it can be reported by the dashboard and
+// printed at the end of a run. All fields are updated with atomic ops since
+// fetch workers touch them concurrently.
+type Counters struct {
+	Queued   int64
+	InFlight int64
+	Done     int64
+	Failed   int64
+	paused   int32
+}
+
+func (c *Counters) setQueued(n int) { atomic.StoreInt64(&c.Queued, int64(n)) }
+func (c *Counters) incInFlight()    { atomic.AddInt64(&c.InFlight, 1) }
+func (c *Counters) decInFlight()    { atomic.AddInt64(&c.InFlight, -1) }
+func (c *Counters) incDone()        { atomic.AddInt64(&c.Done, 1) }
+func (c *Counters) incFailed()      { atomic.AddInt64(&c.Failed, 1) }
+
+// InFlightCount returns the current number of requests in flight.
+func (c *Counters) InFlightCount() int64 { return atomic.LoadInt64(&c.InFlight) }
+
+// DoneCount returns the number of pages successfully scraped so far, used to
+// enforce -max-pages.
+func (c *Counters) DoneCount() int64 { return atomic.LoadInt64(&c.Done) }
+
+func (c *Counters) snapshot() map[string]int64 {
+	return map[string]int64{
+		"queued":    atomic.LoadInt64(&c.Queued),
+		"in_flight": atomic.LoadInt64(&c.InFlight),
+		"done":      atomic.LoadInt64(&c.Done),
+		"failed":    atomic.LoadInt64(&c.Failed),
+	}
+}
+
+// Paused reports whether workers should currently hold off on picking up new
+// work.
+func (c *Counters) Paused() bool {
+	return atomic.LoadInt32(&c.paused) == 1
+}
+
+func (c *Counters) setPaused(paused bool) {
+	if paused {
+		atomic.StoreInt32(&c.paused, 1)
+	} else {
+		atomic.StoreInt32(&c.paused, 0)
+	}
+}
+
+// Dashboard serves a tiny JSON status/control endpoint over HTTP so a long
+// crawl can be monitored and paused/resumed without killing the process.
+type Dashboard struct {
+	addr     string
+	counters *Counters
+}
+
+// NewDashboard creates a dashboard bound to addr (e.g. ":8080"). It does not
+// start listening until Start is called.
+func NewDashboard(addr string, counters *Counters) *Dashboard {
+	return &Dashboard{addr: addr, counters: counters}
+}
+
+// Start launches the dashboard's HTTP server in the background. Listen
+// failures are logged rather than fatal, since the dashboard is a
+// convenience and should never abort a crawl.
+func (d *Dashboard) Start() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		status := d.counters.snapshot()
+		status["paused"] = boolToInt64(d.counters.Paused())
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	})
+	mux.HandleFunc("/pause", func(w http.ResponseWriter, r *http.Request) {
+		d.counters.setPaused(true)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/resume", func(w http.ResponseWriter, r *http.Request) {
+		d.counters.setPaused(false)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	go func() {
+		log.Printf("Dashboard listening on %s", d.addr)
+		if err := http.ListenAndServe(d.addr, mux); err != nil {
+			log.Printf("Dashboard server stopped: %v", err)
+		}
+	}()
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package discovery
+
+import (
+	"log"
+	"net/http"
+)
+
+// Result is everything discovery learned about a site before the crawl's
+// BFS starts.
+type Result struct {
+	// Robots holds the parsed robots.txt rules. Never nil.
+	Robots *Robots
+	// SeedURLs are URLs to seed the frontier with in addition to baseURL.
+	// When an llms.txt is found, this is its curated list and should be
+	// preferred over crawling outward from baseURL.
+	SeedURLs []string
+	// PreferSeeds is true when SeedURLs came from a curated source
+	// (llms.txt) rather than a sitemap, meaning the caller should use them
+	// instead of the normal link-following BFS.
+	PreferSeeds bool
+}
+
+// Discover fetches robots.txt (unless ignoreRobots), follows any sitemaps
+// it points to (falling back to the conventional /sitemap.xml location),
+// and checks for a curated llms.txt/llms-full.txt. llms.txt takes priority
+// over sitemap-derived seeds when both are present.
+func Discover(client *http.Client, baseURL string, ignoreRobots bool) *Result {
+	result := &Result{Robots: &Robots{}}
+
+	if !ignoreRobots {
+		robots, err := FetchRobots(client, baseURL)
+		if err != nil {
+			log.Printf("discovery: error fetching robots.txt: %v", err)
+		} else {
+			result.Robots = robots
+		}
+	}
+
+	if llmsURLs, err := FetchLLMsTxt(client, baseURL); err != nil {
+		log.Printf("discovery: error fetching llms.txt: %v", err)
+	} else if len(llmsURLs) > 0 {
+		log.Printf("discovery: using %d URLs from llms.txt", len(llmsURLs))
+		result.SeedURLs = llmsURLs
+		result.PreferSeeds = true
+		return result
+	}
+
+	sitemaps := result.Robots.Sitemaps
+	if len(sitemaps) == 0 {
+		sitemaps = []string{baseURL + "/sitemap.xml"}
+	}
+
+	var seen = make(map[string]bool)
+	for _, sitemapURL := range sitemaps {
+		urls, err := FetchSitemapURLs(client, sitemapURL)
+		if err != nil {
+			log.Printf("discovery: error fetching sitemap %s: %v", sitemapURL, err)
+			continue
+		}
+		for _, u := range urls {
+			if !seen[u] {
+				seen[u] = true
+				result.SeedURLs = append(result.SeedURLs, u)
+			}
+		}
+	}
+	if len(result.SeedURLs) > 0 {
+		log.Printf("discovery: seeded %d URLs from sitemap", len(result.SeedURLs))
+	}
+
+	return result
+}
@@ -0,0 +1,51 @@
+package discovery
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// llmsMarkdownLink matches the "- [Title](https://...)" entries that
+// llms.txt/llms-full.txt conventionally list their curated pages as.
+var llmsMarkdownLink = regexp.MustCompile(`\[[^\]]*\]\((https?://[^\s)]+)\)`)
+
+// FetchLLMsTxt tries /llms-full.txt then /llms.txt and returns the curated
+// URL list found in whichever responds first with 200. It returns a nil
+// slice (not an error) when neither file exists, since most sites don't
+// publish one yet.
+func FetchLLMsTxt(client *http.Client, baseURL string) ([]string, error) {
+	for _, name := range []string{"llms-full.txt", "llms.txt"} {
+		resp, err := client.Get(strings.TrimRight(baseURL, "/") + "/" + name)
+		if err != nil {
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			continue
+		}
+
+		urls, err := parseLLMsTxt(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(urls) > 0 {
+			return urls, nil
+		}
+	}
+	return nil, nil
+}
+
+func parseLLMsTxt(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	var urls []string
+	for scanner.Scan() {
+		for _, match := range llmsMarkdownLink.FindAllStringSubmatch(scanner.Text(), -1) {
+			urls = append(urls, match[1])
+		}
+	}
+	return urls, scanner.Err()
+}
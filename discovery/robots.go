@@ -0,0 +1,125 @@
+// Package discovery seeds a crawl's frontier before the BFS starts: it
+// honors robots.txt, follows any sitemaps it points to, and prefers an
+// llms.txt curated URL list when a site publishes one.
+package discovery
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Robots holds the parsed rules that apply to this crawler (the "*"
+// user-agent group), plus any Sitemap: entries the file referenced.
+type Robots struct {
+	allow      []string
+	disallow   []string
+	CrawlDelay float64
+	Sitemaps   []string
+}
+
+// FetchRobots fetches and parses baseURL's /robots.txt. A 404 or any other
+// fetch error is not fatal - it's treated as "no rules", since robots.txt
+// is optional and most sites don't bother with one.
+func FetchRobots(client *http.Client, baseURL string) (*Robots, error) {
+	resp, err := client.Get(strings.TrimRight(baseURL, "/") + "/robots.txt")
+	if err != nil {
+		return &Robots{}, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &Robots{}, nil
+	}
+
+	return ParseRobots(resp.Body)
+}
+
+// ParseRobots reads a robots.txt body and extracts the rules for the "*"
+// user-agent group along with every Sitemap: directive (those apply
+// regardless of which group they appear under).
+func ParseRobots(r io.Reader) (*Robots, error) {
+	robots := &Robots{}
+	scanner := bufio.NewScanner(r)
+
+	inWildcardGroup := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		field, value, ok := splitDirective(line)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(field) {
+		case "user-agent":
+			inWildcardGroup = value == "*"
+		case "disallow":
+			if inWildcardGroup && value != "" {
+				robots.disallow = append(robots.disallow, value)
+			}
+		case "allow":
+			if inWildcardGroup && value != "" {
+				robots.allow = append(robots.allow, value)
+			}
+		case "crawl-delay":
+			if inWildcardGroup {
+				if d, err := strconv.ParseFloat(value, 64); err == nil {
+					robots.CrawlDelay = d
+				}
+			}
+		case "sitemap":
+			robots.Sitemaps = append(robots.Sitemaps, value)
+		}
+	}
+
+	return robots, scanner.Err()
+}
+
+func splitDirective(line string) (field, value string, ok bool) {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), true
+}
+
+// Allowed reports whether path may be crawled, using the standard robots.txt
+// longest-match-wins rule: among every Allow/Disallow rule whose prefix
+// matches path, the longest one wins; ties favor Allow.
+func (r *Robots) Allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+
+	bestLen := -1
+	allowed := true
+
+	check := func(rules []string, isAllow bool) {
+		for _, rule := range rules {
+			if !strings.HasPrefix(path, rule) {
+				continue
+			}
+			if len(rule) > bestLen || (len(rule) == bestLen && isAllow) {
+				bestLen = len(rule)
+				allowed = isAllow
+			}
+		}
+	}
+
+	check(r.disallow, false)
+	check(r.allow, true)
+
+	return allowed
+}
+
+// String is used for log messages when a URL is skipped.
+func (r *Robots) String() string {
+	return fmt.Sprintf("robots{allow=%d disallow=%d sitemaps=%d}", len(r.allow), len(r.disallow), len(r.Sitemaps))
+}
@@ -0,0 +1,85 @@
+package discovery
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRobotsAllowedLongestMatchWins(t *testing.T) {
+	robots, err := ParseRobots(strings.NewReader(`
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /
+`))
+	if err != nil {
+		t.Fatalf("ParseRobots: %v", err)
+	}
+
+	cases := []struct {
+		path string
+		want bool
+	}{
+		{"/private/secret", false},
+		{"/private/public/page", true}, // longer Allow beats the shorter Disallow
+		{"/other", false},              // caught by the blanket "Disallow: /"
+	}
+
+	for _, c := range cases {
+		if got := robots.Allowed(c.path); got != c.want {
+			t.Errorf("Allowed(%q) = %v, want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestRobotsAllowedTieFavorsAllow(t *testing.T) {
+	robots, err := ParseRobots(strings.NewReader(`
+User-agent: *
+Disallow: /docs
+Allow: /docs
+`))
+	if err != nil {
+		t.Fatalf("ParseRobots: %v", err)
+	}
+
+	if !robots.Allowed("/docs/page") {
+		t.Error("equal-length Allow/Disallow rules should favor Allow")
+	}
+}
+
+func TestRobotsAllowedNilIsPermissive(t *testing.T) {
+	var robots *Robots
+	if !robots.Allowed("/anything") {
+		t.Error("a nil Robots should allow everything")
+	}
+}
+
+func TestRobotsIgnoresOtherUserAgentGroups(t *testing.T) {
+	robots, err := ParseRobots(strings.NewReader(`
+User-agent: SomeOtherBot
+Disallow: /
+
+User-agent: *
+Allow: /
+`))
+	if err != nil {
+		t.Fatalf("ParseRobots: %v", err)
+	}
+
+	if !robots.Allowed("/anything") {
+		t.Error("rules scoped to another user-agent group should not apply to the '*' group")
+	}
+}
+
+func TestRobotsCrawlDelay(t *testing.T) {
+	robots, err := ParseRobots(strings.NewReader(`
+User-agent: *
+Crawl-delay: 2.5
+`))
+	if err != nil {
+		t.Fatalf("ParseRobots: %v", err)
+	}
+	if robots.CrawlDelay != 2.5 {
+		t.Errorf("CrawlDelay = %v, want 2.5", robots.CrawlDelay)
+	}
+}
@@ -0,0 +1,98 @@
+package discovery
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// sitemapURLSet mirrors <urlset><url><loc>...</loc></url>...</urlset>.
+type sitemapURLSet struct {
+	URLs []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+}
+
+// sitemapIndex mirrors a sitemap index file, which lists child sitemaps
+// instead of pages directly.
+type sitemapIndex struct {
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// maxSitemapDepth bounds how many levels of nested sitemap indexes
+// FetchSitemapURLs will follow, guarding against a misconfigured site
+// pointing a sitemap index back at itself.
+const maxSitemapDepth = 5
+
+// FetchSitemapURLs fetches sitemapURL and returns every page URL it (and
+// any sitemaps it references) lists. Both plain urlset and sitemap_index
+// documents are handled, recursively for index files.
+func FetchSitemapURLs(client *http.Client, sitemapURL string) ([]string, error) {
+	return fetchSitemapURLs(client, sitemapURL, 0)
+}
+
+func fetchSitemapURLs(client *http.Client, sitemapURL string, depth int) ([]string, error) {
+	if depth >= maxSitemapDepth {
+		return nil, fmt.Errorf("sitemap nesting too deep at %s", sitemapURL)
+	}
+
+	resp, err := client.Get(sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching sitemap %s: status %d", sitemapURL, resp.StatusCode)
+	}
+
+	body, err := decodeXML(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body.urlSet.URLs) > 0 {
+		urls := make([]string, 0, len(body.urlSet.URLs))
+		for _, u := range body.urlSet.URLs {
+			urls = append(urls, u.Loc)
+		}
+		return urls, nil
+	}
+
+	var urls []string
+	for _, child := range body.index.Sitemaps {
+		childURLs, err := fetchSitemapURLs(client, child.Loc, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, childURLs...)
+	}
+	return urls, nil
+}
+
+type decodedSitemap struct {
+	urlSet sitemapURLSet
+	index  sitemapIndex
+}
+
+// decodeXML reads the sitemap body once and unmarshals it against both
+// known shapes; exactly one will come back populated depending on the root
+// element (<urlset> vs <sitemapindex>).
+func decodeXML(resp *http.Response) (decodedSitemap, error) {
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return decodedSitemap{}, err
+	}
+
+	var result decodedSitemap
+	if err := xml.Unmarshal(raw, &result.urlSet); err != nil {
+		return decodedSitemap{}, err
+	}
+	if err := xml.Unmarshal(raw, &result.index); err != nil {
+		return decodedSitemap{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Emitter renders scraped pages to one on-disk output format. OpenPage is
+// called once per page as it's scraped (in the order workers finish, not
+// crawl order); Close is called once after the crawl finishes and flushes
+// whatever the emitter buffered along the way.
+type Emitter interface {
+	OpenPage(page Page) error
+	Close() error
+}
+
+// emitterFactories maps a --format name to a constructor. Each constructor
+// is handed everything an emitter might need to place its output alongside
+// the primary Markdown output.
+var emitterFactories = map[string]func(opts emitterOptions) (Emitter, error){
+	"md":    func(o emitterOptions) (Emitter, error) { return newMarkdownEmitter(o), nil },
+	"jsonl": func(o emitterOptions) (Emitter, error) { return newJSONLEmitter(o), nil },
+	"html":  func(o emitterOptions) (Emitter, error) { return newHTMLEmitter(o), nil },
+	"epub":  func(o emitterOptions) (Emitter, error) { return newEPUBEmitter(o) },
+	"pdf":   func(o emitterOptions) (Emitter, error) { return newPDFEmitter(o) },
+}
+
+// emitterOptions is the shared construction context every emitter needs.
+type emitterOptions struct {
+	outputPath   string
+	outputDir    string
+	organization Organization
+	baseURL      string
+	browserPath  string
+	incremental  bool
+	singlePage   bool
+}
+
+// resolveEmitters builds one Emitter per comma-separated name in formats
+// (e.g. "md,jsonl,epub"), in the order given. An empty formats defaults to
+// the historical Markdown-only behavior.
+func resolveEmitters(formats string, opts emitterOptions) ([]Emitter, error) {
+	names := strings.Split(formats, ",")
+
+	var emitters []Emitter
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		factory, ok := emitterFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown output format %q", name)
+		}
+		emitter, err := factory(opts)
+		if err != nil {
+			return nil, fmt.Errorf("setting up %s output: %v", name, err)
+		}
+		emitters = append(emitters, emitter)
+	}
+
+	if len(emitters) == 0 {
+		emitters = append(emitters, newMarkdownEmitter(opts))
+	}
+
+	return emitters, nil
+}
+
+// docTitle derives the "# Documentation: host/path" style title used by
+// several emitters from the crawl's base URL.
+func docTitle(baseURL string) string {
+	title := strings.TrimPrefix(baseURL, "https://")
+	title = strings.TrimPrefix(title, "http://")
+	return title
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"html"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-shiori/go-epub"
+)
+
+// epubEmitter assembles the crawl into a single EPUB, one chapter per page,
+// reusing the same Markdown-to-HTML conversion as the HTML emitter since
+// go-epub's AddSection takes HTML.
+type epubEmitter struct {
+	opts emitterOptions
+
+	mu   sync.Mutex
+	book *epub.Epub
+	err  error
+}
+
+func newEPUBEmitter(opts emitterOptions) (*epubEmitter, error) {
+	book, err := epub.NewEpub(docTitle(opts.baseURL))
+	if err != nil {
+		return nil, err
+	}
+	return &epubEmitter{opts: opts, book: book}, nil
+}
+
+func (e *epubEmitter) OpenPage(page Page) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	body := "<p class=\"source\">" + html.EscapeString(page.URL) + "</p>" + markdownToHTML(page.Content)
+	title := page.Title
+	if title == "" {
+		title = page.URL
+	}
+	_, err := e.book.AddSection(body, title, "", "")
+	return err
+}
+
+func (e *epubEmitter) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	path := filepath.Join(e.opts.outputDir, "docs.epub")
+	return e.book.Write(path)
+}
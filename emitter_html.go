@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// htmlEmitter renders the whole crawl as a single self-contained HTML file:
+// a TOC sidebar down the left, and every page's content in its own
+// <section> on the right. It buffers pages in memory and writes nothing
+// until Close, since the sidebar needs every page's title up front.
+type htmlEmitter struct {
+	opts emitterOptions
+
+	mu    sync.Mutex
+	pages []Page
+}
+
+func newHTMLEmitter(opts emitterOptions) *htmlEmitter {
+	return &htmlEmitter{opts: opts}
+}
+
+func (e *htmlEmitter) OpenPage(page Page) error {
+	e.mu.Lock()
+	e.pages = append(e.pages, page)
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *htmlEmitter) Close() error {
+	e.mu.Lock()
+	pages := make([]Page, len(e.pages))
+	copy(pages, e.pages)
+	e.mu.Unlock()
+
+	path := filepath.Join(e.opts.outputDir, "docs.html")
+	return writeContentToFile(path, renderHTMLDoc(docTitle(e.opts.baseURL), pages))
+}
+
+func renderHTMLDoc(title string, pages []Page) string {
+	var sidebar, sections strings.Builder
+	for i, page := range pages {
+		anchor := fmt.Sprintf("page-%d", i)
+		sidebar.WriteString(fmt.Sprintf("<li><a href=\"#%s\">%s</a></li>\n", anchor, html.EscapeString(page.Title)))
+		sections.WriteString(fmt.Sprintf("<section id=\"%s\">\n", anchor))
+		sections.WriteString(fmt.Sprintf("<p class=\"source\"><a href=\"%s\">%s</a></p>\n", html.EscapeString(page.URL), html.EscapeString(page.URL)))
+		sections.WriteString(markdownToHTML(page.Content))
+		sections.WriteString("</section>\n")
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { display: flex; margin: 0; font-family: sans-serif; }
+nav { width: 260px; flex-shrink: 0; overflow-y: auto; height: 100vh; border-right: 1px solid #ddd; padding: 1em; box-sizing: border-box; }
+nav ul { list-style: none; padding-left: 0; }
+main { flex: 1; padding: 1em 2em; max-width: 900px; }
+.source { color: #888; font-size: 0.85em; }
+pre { background: #f5f5f5; padding: 0.75em; overflow-x: auto; }
+</style>
+</head>
+<body>
+<nav><ul>
+%s</ul></nav>
+<main>
+<h1>%s</h1>
+%s</main>
+</body>
+</html>
+`, html.EscapeString(title), sidebar.String(), html.EscapeString(title), sections.String())
+}
+
+// markdownToHTML does a minimal, line-oriented conversion of the Markdown
+// renderPage produces (headings, fenced code blocks, "- " list items,
+// plain paragraphs) into HTML. It doesn't aim to handle arbitrary Markdown,
+// only the shapes renderPage actually emits.
+func markdownToHTML(md string) string {
+	var out strings.Builder
+	inCode := false
+	inList := false
+
+	closeList := func() {
+		if inList {
+			out.WriteString("</ul>\n")
+			inList = false
+		}
+	}
+
+	for _, line := range strings.Split(md, "\n") {
+		if strings.HasPrefix(line, "```") {
+			if inCode {
+				out.WriteString("</pre>\n")
+			} else {
+				closeList()
+				out.WriteString("<pre>")
+			}
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || trimmed == "---":
+			closeList()
+		case strings.HasPrefix(trimmed, "###### "):
+			closeList()
+			out.WriteString(fmt.Sprintf("<h6>%s</h6>\n", html.EscapeString(trimmed[7:])))
+		case strings.HasPrefix(trimmed, "##### "):
+			closeList()
+			out.WriteString(fmt.Sprintf("<h5>%s</h5>\n", html.EscapeString(trimmed[6:])))
+		case strings.HasPrefix(trimmed, "#### "):
+			closeList()
+			out.WriteString(fmt.Sprintf("<h4>%s</h4>\n", html.EscapeString(trimmed[5:])))
+		case strings.HasPrefix(trimmed, "### "):
+			closeList()
+			out.WriteString(fmt.Sprintf("<h3>%s</h3>\n", html.EscapeString(trimmed[4:])))
+		case strings.HasPrefix(trimmed, "## "):
+			closeList()
+			out.WriteString(fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(trimmed[3:])))
+		case strings.HasPrefix(trimmed, "# "):
+			closeList()
+			out.WriteString(fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(trimmed[2:])))
+		case strings.HasPrefix(trimmed, "> "):
+			closeList()
+			out.WriteString(fmt.Sprintf("<blockquote>%s</blockquote>\n", html.EscapeString(trimmed[2:])))
+		case strings.HasPrefix(trimmed, "- "):
+			if !inList {
+				out.WriteString("<ul>\n")
+				inList = true
+			}
+			out.WriteString(fmt.Sprintf("<li>%s</li>\n", html.EscapeString(trimmed[2:])))
+		default:
+			closeList()
+			out.WriteString(fmt.Sprintf("<p>%s</p>\n", html.EscapeString(trimmed)))
+		}
+	}
+	closeList()
+
+	return out.String()
+}
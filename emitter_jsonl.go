@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// jsonlEntry is the shape of one line in the JSONL output: enough for a RAG
+// pipeline to ingest a page without re-parsing Markdown.
+type jsonlEntry struct {
+	Title       string      `json:"title"`
+	URL         string      `json:"url"`
+	Level       int         `json:"level"`
+	ContentMD   string      `json:"content_md"`
+	ContentText string      `json:"content_text"`
+	Headings    []Heading   `json:"headings"`
+	CodeBlocks  []CodeBlock `json:"code_blocks"`
+}
+
+// jsonlEmitter writes one JSON object per line to docs.jsonl, suitable for
+// bulk-loading into a vector store or search index.
+type jsonlEmitter struct {
+	mu   sync.Mutex
+	file *os.File
+	err  error
+}
+
+func newJSONLEmitter(opts emitterOptions) *jsonlEmitter {
+	path := filepath.Join(opts.outputDir, "docs.jsonl")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return &jsonlEmitter{err: err}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return &jsonlEmitter{err: err}
+	}
+	return &jsonlEmitter{file: f}
+}
+
+func (e *jsonlEmitter) OpenPage(page Page) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	line, err := json.Marshal(jsonlEntry{
+		Title:       page.Title,
+		URL:         page.URL,
+		Level:       page.Level,
+		ContentMD:   page.Content,
+		ContentText: page.ContentText,
+		Headings:    page.Headings,
+		CodeBlocks:  page.CodeBlocks,
+	})
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.file.Write(append(line, '\n'))
+	return err
+}
+
+func (e *jsonlEmitter) Close() error {
+	if e.file == nil {
+		return e.err
+	}
+	return e.file.Close()
+}
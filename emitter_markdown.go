@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// markdownEmitter is the original (and default) output format: either one
+// big Markdown file, or one file per page plus an index.md table of
+// contents.
+type markdownEmitter struct {
+	opts emitterOptions
+
+	mu        sync.Mutex
+	singleBuf strings.Builder
+	pages     []Page
+}
+
+func newMarkdownEmitter(opts emitterOptions) *markdownEmitter {
+	return &markdownEmitter{opts: opts}
+}
+
+func (e *markdownEmitter) OpenPage(page Page) error {
+	switch e.opts.organization {
+	case SingleFile:
+		// The single output file is one aggregate; an unchanged page still
+		// contributes its (cached) content so the file doesn't lose pages
+		// that didn't change this run.
+		e.mu.Lock()
+		e.singleBuf.WriteString(page.Content)
+		e.mu.Unlock()
+	case ByChapters, ByPages:
+		// Each page is its own file, so an unchanged page simply has
+		// nothing new to write - skip the physical write, but still track
+		// it below for the index.
+		if !e.opts.incremental || page.Changed {
+			outputPath := filepath.Join(e.opts.outputDir, page.Filename)
+			if err := writeContentToFile(outputPath, page.Content); err != nil {
+				return err
+			}
+		}
+	}
+
+	e.mu.Lock()
+	e.pages = append(e.pages, page)
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *markdownEmitter) Close() error {
+	e.mu.Lock()
+	pages := make([]Page, len(e.pages))
+	copy(pages, e.pages)
+	e.mu.Unlock()
+
+	if e.opts.organization == SingleFile {
+		if e.opts.singlePage {
+			// -p writes the one page's content straight to outputPath, same
+			// as the multi-page single-file case minus the synthesized
+			// header - there's only one page, so a "Documentation: host"
+			// header followed immediately by that page's own title reads as
+			// redundant.
+			return writeContentToFile(e.opts.outputPath, e.singleBuf.String())
+		}
+		header := fmt.Sprintf("# Documentation: %s\n\n", docTitle(e.opts.baseURL))
+		return writeContentToFile(e.opts.outputPath, header+e.singleBuf.String())
+	}
+
+	if e.opts.singlePage {
+		// -p writes the one page straight to outputDir/<filename>; there's
+		// nothing to index.
+		return nil
+	}
+
+	return e.writeIndex(pages)
+}
+
+// writeIndex regenerates index.md: a full table of contents, plus (under
+// -incremental) a "changed since last run" section.
+func (e *markdownEmitter) writeIndex(pages []Page) error {
+	indexPath := filepath.Join(e.opts.outputDir, "index.md")
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("# Documentation: %s\n\n", docTitle(e.opts.baseURL)))
+	content.WriteString("## Table of Contents\n\n")
+
+	for _, page := range pages {
+		indent := strings.Repeat("  ", page.Level-1)
+		content.WriteString(fmt.Sprintf("%s- [%s](%s) - [source](%s)\n",
+			indent, page.Title, page.Filename, page.URL))
+	}
+
+	if e.opts.incremental {
+		var changed []Page
+		for _, page := range pages {
+			if page.Changed {
+				changed = append(changed, page)
+			}
+		}
+		content.WriteString("\n## Changed Since Last Run\n\n")
+		if len(changed) == 0 {
+			content.WriteString("No pages changed since the last run.\n")
+		} else {
+			for _, page := range changed {
+				content.WriteString(fmt.Sprintf("- [%s](%s) - [source](%s)\n",
+					page.Title, page.Filename, page.URL))
+			}
+		}
+	}
+
+	return writeContentToFile(indexPath, content.String())
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// pdfEmitter assembles the crawl into a single PDF by rendering the same
+// self-contained HTML the htmlEmitter produces, then driving headless
+// Chrome's print-to-PDF over it. It runs its own browser instance rather
+// than reusing the scraper's fetcher, since -render js and --format pdf are
+// independent choices (a plain http-fetched crawl can still emit a PDF).
+type pdfEmitter struct {
+	opts emitterOptions
+
+	mu    sync.Mutex
+	pages []Page
+
+	allocCtx    context.Context
+	cancelAlloc context.CancelFunc
+	browserCtx  context.Context
+	cancelBrwsr context.CancelFunc
+}
+
+func newPDFEmitter(opts emitterOptions) (*pdfEmitter, error) {
+	chromeOpts := append(chromedp.DefaultExecAllocatorOptions[:])
+	if opts.browserPath != "" {
+		chromeOpts = append(chromeOpts, chromedp.ExecPath(opts.browserPath))
+	}
+
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), chromeOpts...)
+	browserCtx, cancelBrwsr := chromedp.NewContext(allocCtx)
+
+	if err := chromedp.Run(browserCtx); err != nil {
+		cancelBrwsr()
+		cancelAlloc()
+		return nil, fmt.Errorf("starting headless Chrome for PDF output: %v", err)
+	}
+
+	return &pdfEmitter{
+		opts:        opts,
+		allocCtx:    allocCtx,
+		cancelAlloc: cancelAlloc,
+		browserCtx:  browserCtx,
+		cancelBrwsr: cancelBrwsr,
+	}, nil
+}
+
+func (e *pdfEmitter) OpenPage(p Page) error {
+	e.mu.Lock()
+	e.pages = append(e.pages, p)
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *pdfEmitter) Close() error {
+	defer e.cancelBrwsr()
+	defer e.cancelAlloc()
+
+	e.mu.Lock()
+	pages := make([]Page, len(e.pages))
+	copy(pages, e.pages)
+	e.mu.Unlock()
+
+	htmlPath := filepath.Join(e.opts.outputDir, ".docscrap-pdf-source.html")
+	if err := writeContentToFile(htmlPath, renderHTMLDoc(docTitle(e.opts.baseURL), pages)); err != nil {
+		return err
+	}
+	defer os.Remove(htmlPath)
+
+	absPath, err := filepath.Abs(htmlPath)
+	if err != nil {
+		return err
+	}
+
+	tabCtx, cancel := chromedp.NewContext(e.browserCtx)
+	defer cancel()
+
+	var pdfData []byte
+	err = chromedp.Run(tabCtx,
+		chromedp.Navigate("file://"+absPath),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			data, _, err := page.PrintToPDF().WithPrintBackground(true).Do(ctx)
+			pdfData = data
+			return err
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(e.opts.outputDir, "docs.pdf"), pdfData, 0644)
+}
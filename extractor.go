@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Extractor pulls a single Page's structured content out of an already
+// chrome-stripped document. Different doc-site generators lay out their
+// main content differently, so each extractor only needs to know how to
+// find the right container - the Markdown conversion itself is shared via
+// renderPage.
+type Extractor interface {
+	Extract(doc *goquery.Document, url string) (Page, error)
+}
+
+var extractorRegistry = map[string]Extractor{
+	"docusaurus": DocusaurusExtractor{},
+	"mkdocs":     MkDocsExtractor{},
+	"sphinx":     SphinxExtractor{},
+	"gitbook":    GitBookExtractor{},
+	"generic":    GenericExtractor{},
+}
+
+// resolveExtractor picks the extractor to use for a page. An explicit,
+// known name always wins; "auto" (and any unrecognized name) falls back to
+// sniffing the page for a known generator and, failing that, the generic
+// readability-style extractor.
+func resolveExtractor(name string, doc *goquery.Document) Extractor {
+	if name != "" && name != "auto" {
+		if e, ok := extractorRegistry[name]; ok {
+			return e
+		}
+	}
+
+	if detected := detectExtractor(doc); detected != "" {
+		return extractorRegistry[detected]
+	}
+
+	return extractorRegistry["generic"]
+}
+
+// detectExtractor sniffs the <meta name="generator"> tag and a few
+// generator-specific DOM fingerprints left in the body. It returns "" when
+// nothing recognizable is found, letting the caller fall back to generic.
+func detectExtractor(doc *goquery.Document) string {
+	generator, _ := doc.Find(`meta[name="generator"]`).Attr("content")
+	generator = strings.ToLower(generator)
+	bodyClass, _ := doc.Find("body").Attr("class")
+	bodyClass = strings.ToLower(bodyClass)
+
+	switch {
+	case strings.Contains(generator, "docusaurus"), doc.Find(".theme-doc-markdown").Length() > 0:
+		return "docusaurus"
+	case strings.Contains(generator, "mkdocs"), doc.Find(".md-content__inner").Length() > 0:
+		return "mkdocs"
+	case strings.Contains(generator, "sphinx"), strings.Contains(bodyClass, "sphinx"), doc.Find(".rst-content, div[role=\"main\"].document").Length() > 0:
+		return "sphinx"
+	case strings.Contains(generator, "gitbook"), doc.Find(".gitbook-content, .markdown-section").Length() > 0:
+		return "gitbook"
+	}
+	return ""
+}
+
+// renderPage converts container's block content into a Page's Markdown
+// body. It's the conversion logic every extractor shares; only the
+// container selection differs between them.
+func renderPage(container *goquery.Selection, url string) Page {
+	var content strings.Builder
+	var plainText strings.Builder
+	var headings []Heading
+	var codeBlocks []CodeBlock
+	content.WriteString(fmt.Sprintf("\n## Source: %s\n\n", url))
+
+	var title string
+	if titleElem := container.Find("h1").First(); titleElem.Length() > 0 {
+		title = strings.TrimSpace(titleElem.Text())
+		content.WriteString(fmt.Sprintf("# %s\n\n", title))
+		plainText.WriteString(title + "\n\n")
+	}
+
+	container.Find("h2, h3, h4, h5, h6, p, pre, ul, ol, code, blockquote").Each(func(i int, sel *goquery.Selection) {
+		switch goquery.NodeName(sel) {
+		case "h2", "h3", "h4", "h5", "h6":
+			level := int(sel.Get(0).Data[1] - '0')
+			text := strings.TrimSpace(sel.Text())
+			if text != "" {
+				content.WriteString(fmt.Sprintf("%s %s\n\n", strings.Repeat("#", level), text))
+				plainText.WriteString(text + "\n\n")
+				headings = append(headings, Heading{Level: level, Text: text})
+			}
+		case "p":
+			text := strings.TrimSpace(sel.Text())
+			if text != "" {
+				content.WriteString(text + "\n\n")
+				plainText.WriteString(text + "\n\n")
+			}
+		case "pre":
+			if codeBlock := sel.Find("code"); codeBlock.Length() > 0 {
+				lang := ""
+				if className, exists := codeBlock.Attr("class"); exists {
+					langClasses := []string{"language-", "lang-", "brush:"}
+					for _, prefix := range langClasses {
+						if strings.Contains(className, prefix) {
+							parts := strings.Split(className, prefix)
+							if len(parts) > 1 {
+								lang = strings.Split(parts[1], " ")[0]
+								break
+							}
+						}
+					}
+				}
+				code := strings.TrimSpace(codeBlock.Text())
+				if code != "" {
+					if lang != "" {
+						content.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", lang, code))
+					} else {
+						content.WriteString(fmt.Sprintf("```\n%s\n```\n\n", code))
+					}
+					codeBlocks = append(codeBlocks, CodeBlock{Lang: lang, Code: code})
+				}
+			}
+		case "ul", "ol":
+			sel.Find("li").Each(func(_ int, li *goquery.Selection) {
+				text := strings.TrimSpace(li.Text())
+				if text != "" {
+					content.WriteString("- " + text + "\n")
+					plainText.WriteString(text + "\n")
+				}
+			})
+			content.WriteString("\n")
+		case "blockquote":
+			text := strings.TrimSpace(sel.Text())
+			if text != "" {
+				for _, line := range strings.Split(text, "\n") {
+					content.WriteString("> " + strings.TrimSpace(line) + "\n")
+				}
+				content.WriteString("\n")
+				plainText.WriteString(text + "\n\n")
+			}
+		}
+	})
+
+	content.WriteString("---\n\n")
+
+	filename := sanitizeFilename(title)
+	if filename == "" {
+		filename = sanitizeFilename(filepath.Base(url))
+	}
+	filename += ".md"
+
+	return Page{
+		Title:       title,
+		Content:     content.String(),
+		URL:         url,
+		Filename:    filename,
+		ContentText: strings.TrimSpace(plainText.String()),
+		Headings:    headings,
+		CodeBlocks:  codeBlocks,
+	}
+}
+
+// firstNonEmpty returns the first selection in candidates that matched at
+// least one element, or doc's <body> if none did.
+func firstNonEmpty(doc *goquery.Document, candidates ...*goquery.Selection) *goquery.Selection {
+	for _, c := range candidates {
+		if c.Length() > 0 {
+			return c.First()
+		}
+	}
+	return doc.Find("body")
+}
@@ -0,0 +1,52 @@
+package main
+
+import "github.com/PuerkitoBio/goquery"
+
+// DocusaurusExtractor targets Docusaurus's themed doc markdown container,
+// including the versioned-docs layout which nests it the same way.
+type DocusaurusExtractor struct{}
+
+func (DocusaurusExtractor) Extract(doc *goquery.Document, url string) (Page, error) {
+	container := firstNonEmpty(doc,
+		doc.Find(".theme-doc-markdown"),
+		doc.Find("article"),
+	)
+	return renderPage(container, url), nil
+}
+
+// MkDocsExtractor targets the MkDocs Material theme's content column.
+type MkDocsExtractor struct{}
+
+func (MkDocsExtractor) Extract(doc *goquery.Document, url string) (Page, error) {
+	container := firstNonEmpty(doc,
+		doc.Find(".md-content__inner"),
+		doc.Find("article"),
+	)
+	return renderPage(container, url), nil
+}
+
+// SphinxExtractor targets Sphinx/ReadTheDocs output, including both the
+// classic theme (.document) and furo/RTD (.rst-content, [role="main"]).
+type SphinxExtractor struct{}
+
+func (SphinxExtractor) Extract(doc *goquery.Document, url string) (Page, error) {
+	container := firstNonEmpty(doc,
+		doc.Find(".rst-content"),
+		doc.Find(`[role="main"]`),
+		doc.Find(".document"),
+	)
+	return renderPage(container, url), nil
+}
+
+// GitBookExtractor targets both the legacy GitBook (.markdown-section) and
+// current GitBook (.gitbook-content) markup.
+type GitBookExtractor struct{}
+
+func (GitBookExtractor) Extract(doc *goquery.Document, url string) (Page, error) {
+	container := firstNonEmpty(doc,
+		doc.Find(".gitbook-content"),
+		doc.Find(".markdown-section"),
+		doc.Find("#page-content"),
+	)
+	return renderPage(container, url), nil
+}
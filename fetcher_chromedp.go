@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// chromedpFetcher renders a URL in headless Chrome before handing the
+// resulting HTML back to the existing goquery pipeline. It keeps a single
+// browser process alive (via browserCtx) and opens a fresh tab per Fetch
+// call, so concurrent workers under -render js don't each pay browser
+// startup cost.
+type chromedpFetcher struct {
+	allocCtx    context.Context
+	cancelAlloc context.CancelFunc
+	browserCtx  context.Context
+	cancelBrwsr context.CancelFunc
+
+	waitSelector string
+	waitMS       int
+}
+
+// newChromedpFetcher launches a single headless Chrome instance. browserPath
+// overrides the auto-detected Chrome/Chromium binary when set.
+func newChromedpFetcher(browserPath, waitSelector string, waitMS int) (*chromedpFetcher, error) {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:])
+	if browserPath != "" {
+		opts = append(opts, chromedp.ExecPath(browserPath))
+	}
+
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), opts...)
+	browserCtx, cancelBrwsr := chromedp.NewContext(allocCtx)
+
+	// Force the browser to actually start now rather than lazily on the
+	// first real fetch, so startup failures surface immediately.
+	if err := chromedp.Run(browserCtx); err != nil {
+		cancelBrwsr()
+		cancelAlloc()
+		return nil, err
+	}
+
+	return &chromedpFetcher{
+		allocCtx:     allocCtx,
+		cancelAlloc:  cancelAlloc,
+		browserCtx:   browserCtx,
+		cancelBrwsr:  cancelBrwsr,
+		waitSelector: waitSelector,
+		waitMS:       waitMS,
+	}, nil
+}
+
+func (f *chromedpFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	tabCtx, cancel := chromedp.NewContext(f.browserCtx)
+	defer cancel()
+
+	actions := []chromedp.Action{chromedp.Navigate(url)}
+	switch {
+	case f.waitSelector != "":
+		actions = append(actions, chromedp.WaitVisible(f.waitSelector, chromedp.ByQuery))
+	case f.waitMS > 0:
+		actions = append(actions, chromedp.Sleep(time.Duration(f.waitMS)*time.Millisecond))
+	}
+
+	var html string
+	actions = append(actions, chromedp.OuterHTML("html", &html, chromedp.ByQuery))
+
+	if err := chromedp.Run(tabCtx, actions...); err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(strings.NewReader(html)), nil
+}
+
+// Close shuts down the shared browser instance. It should be called once,
+// after the crawl finishes.
+func (f *chromedpFetcher) Close() error {
+	f.cancelBrwsr()
+	f.cancelAlloc()
+	return nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FilterRule is one include/exclude entry in a URLFilter's rule list.
+// Include is false for an exclude rule. Rules are matched against a URL's
+// path in declaration order; the last matching rule wins, the same
+// last-match-wins semantics rsync filter rules use.
+type FilterRule struct {
+	Include bool
+	re      *regexp.Regexp
+}
+
+// newFilterRule compiles pattern into a FilterRule. When regex is false,
+// pattern is a glob (supporting "**" to match across path segments, "*" to
+// match within one, and "?" for a single character); when regex is true,
+// pattern is used as-is.
+func newFilterRule(pattern string, include bool, regex bool) (FilterRule, error) {
+	var re *regexp.Regexp
+	var err error
+	if regex {
+		re, err = regexp.Compile(pattern)
+	} else {
+		re, err = globToRegexp(pattern)
+	}
+	if err != nil {
+		return FilterRule{}, err
+	}
+	return FilterRule{Include: include, re: re}, nil
+}
+
+// globToRegexp translates a glob pattern into an anchored regexp. "**"
+// matches any number of path segments, "*" matches within a single segment,
+// "?" matches one non-slash character, and anything else is matched
+// literally.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// URLFilter decides whether a discovered URL should be crawled at all
+// (Allowed, based on include/exclude rules) and whether the crawl has
+// reached the caller's configured -max-depth / -max-pages limits.
+type URLFilter struct {
+	rules    []FilterRule
+	maxDepth int
+	maxPages int
+}
+
+// NewURLFilter builds a URLFilter from an ordered rule list plus the crawl's
+// depth/page caps (either <= 0 disables that cap).
+func NewURLFilter(rules []FilterRule, maxDepth, maxPages int) *URLFilter {
+	return &URLFilter{rules: rules, maxDepth: maxDepth, maxPages: maxPages}
+}
+
+// Allowed reports whether path is permitted by the rule list. With no
+// matching rule, or no rules at all, a URL is allowed - matching the
+// scraper's historical allow-everything-on-host default.
+func (f *URLFilter) Allowed(path string) bool {
+	allowed := true
+	for _, rule := range f.rules {
+		if rule.re.MatchString(path) {
+			allowed = rule.Include
+		}
+	}
+	return allowed
+}
+
+// DepthAllowed reports whether depth is within -max-depth (or there's no
+// limit).
+func (f *URLFilter) DepthAllowed(depth int) bool {
+	return f.maxDepth <= 0 || depth <= f.maxDepth
+}
+
+// PagesExhausted reports whether scraped has already reached -max-pages (or
+// there's no limit).
+func (f *URLFilter) PagesExhausted(scraped int64) bool {
+	return f.maxPages > 0 && scraped >= int64(f.maxPages)
+}
+
+// ruleFlag is a flag.Value that compiles each occurrence of an
+// -include/-exclude/-include-regex/-exclude-regex flag into a FilterRule and
+// appends it to a shared slice, so rules from different flag names still end
+// up in the command-line's actual left-to-right order.
+type ruleFlag struct {
+	include bool
+	regex   bool
+	rules   *[]FilterRule
+}
+
+func (f *ruleFlag) String() string { return "" }
+
+func (f *ruleFlag) Set(pattern string) error {
+	rule, err := newFilterRule(pattern, f.include, f.regex)
+	if err != nil {
+		return err
+	}
+	*f.rules = append(*f.rules, rule)
+	return nil
+}
+
+// rcFile is the schema for a .docscraprc profile: a flat, ordered list of
+// rules (so include/exclude declaration order survives round through
+// YAML/JSON) plus the depth/page caps.
+type rcFile struct {
+	Rules    []rcRule `yaml:"rules" json:"rules"`
+	MaxDepth int      `yaml:"max_depth,omitempty" json:"max_depth,omitempty"`
+	MaxPages int      `yaml:"max_pages,omitempty" json:"max_pages,omitempty"`
+}
+
+// rcRule is one entry of an rcFile's rule list. Exactly one field is
+// expected to be set.
+type rcRule struct {
+	Include      string `yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude      string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	IncludeRegex string `yaml:"include_regex,omitempty" json:"include_regex,omitempty"`
+	ExcludeRegex string `yaml:"exclude_regex,omitempty" json:"exclude_regex,omitempty"`
+}
+
+// rules compiles an rcFile's rule list into FilterRules, in declaration
+// order.
+func (rc rcFile) rules() ([]FilterRule, error) {
+	var out []FilterRule
+	for _, r := range rc.Rules {
+		switch {
+		case r.Include != "":
+			rule, err := newFilterRule(r.Include, true, false)
+			if err != nil {
+				return nil, fmt.Errorf("include %q: %v", r.Include, err)
+			}
+			out = append(out, rule)
+		case r.Exclude != "":
+			rule, err := newFilterRule(r.Exclude, false, false)
+			if err != nil {
+				return nil, fmt.Errorf("exclude %q: %v", r.Exclude, err)
+			}
+			out = append(out, rule)
+		case r.IncludeRegex != "":
+			rule, err := newFilterRule(r.IncludeRegex, true, true)
+			if err != nil {
+				return nil, fmt.Errorf("include_regex %q: %v", r.IncludeRegex, err)
+			}
+			out = append(out, rule)
+		case r.ExcludeRegex != "":
+			rule, err := newFilterRule(r.ExcludeRegex, false, true)
+			if err != nil {
+				return nil, fmt.Errorf("exclude_regex %q: %v", r.ExcludeRegex, err)
+			}
+			out = append(out, rule)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import "testing"
+
+func TestGlobToRegexp(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/docs/*", "/docs/intro", true},
+		{"/docs/*", "/docs/intro/deep", false},
+		{"/docs/**", "/docs/intro/deep", true},
+		{"/docs/**", "/other", false},
+		{"/docs/?.html", "/docs/a.html", true},
+		{"/docs/?.html", "/docs/ab.html", false},
+		{"/docs/*", "/docsx", false},
+	}
+
+	for _, c := range cases {
+		re, err := globToRegexp(c.pattern)
+		if err != nil {
+			t.Fatalf("globToRegexp(%q): %v", c.pattern, err)
+		}
+		if got := re.MatchString(c.path); got != c.want {
+			t.Errorf("globToRegexp(%q).MatchString(%q) = %v, want %v", c.pattern, c.path, got, c.want)
+		}
+	}
+}
+
+func TestURLFilterAllowedLastMatchWins(t *testing.T) {
+	rules := []FilterRule{}
+	add := func(pattern string, include bool) {
+		rule, err := newFilterRule(pattern, include, false)
+		if err != nil {
+			t.Fatalf("newFilterRule(%q): %v", pattern, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	add("/docs/**", true)
+	add("/docs/internal/**", false)
+	filter := NewURLFilter(rules, 0, 0)
+
+	if !filter.Allowed("/docs/intro") {
+		t.Error("expected /docs/intro to be allowed")
+	}
+	if filter.Allowed("/docs/internal/secrets") {
+		t.Error("expected /docs/internal/secrets to be excluded by the later, more specific rule")
+	}
+	if !filter.Allowed("/unrelated") {
+		t.Error("a path matching no rule should be allowed by default")
+	}
+}
+
+func TestURLFilterDepthAndPageCaps(t *testing.T) {
+	filter := NewURLFilter(nil, 2, 3)
+
+	if !filter.DepthAllowed(2) {
+		t.Error("depth equal to maxDepth should be allowed")
+	}
+	if filter.DepthAllowed(3) {
+		t.Error("depth beyond maxDepth should not be allowed")
+	}
+	if filter.PagesExhausted(2) {
+		t.Error("scraped count below maxPages should not be exhausted")
+	}
+	if !filter.PagesExhausted(3) {
+		t.Error("scraped count at maxPages should be exhausted")
+	}
+
+	unlimited := NewURLFilter(nil, 0, 0)
+	if !unlimited.DepthAllowed(1000) {
+		t.Error("maxDepth <= 0 should disable the depth cap")
+	}
+	if unlimited.PagesExhausted(1000) {
+		t.Error("maxPages <= 0 should disable the page cap")
+	}
+}
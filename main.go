@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"math/rand"
 	"net/http"
@@ -11,9 +13,11 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/SellMindsAI/DocScraper/discovery"
 )
 
 type Organization int
@@ -30,38 +34,164 @@ type Page struct {
 	URL      string
 	Filename string
 	Level    int
+	// Changed is only meaningful under -incremental: false means the page
+	// was unchanged since the last run (304, or same content hash) and was
+	// not rewritten to disk.
+	Changed bool
+
+	// ContentText, Headings and CodeBlocks are the structured form of
+	// Content, populated alongside it by renderPage so emitters that need
+	// more than rendered Markdown (JSONL, HTML, EPUB) don't have to
+	// re-parse it.
+	ContentText string
+	Headings    []Heading
+	CodeBlocks  []CodeBlock
+}
+
+// Heading is one heading extracted from a page's content, in document order.
+type Heading struct {
+	Level int
+	Text  string
+}
+
+// CodeBlock is one fenced code block extracted from a page's content, in
+// document order. Lang is empty when no language could be detected from the
+// block's class attribute.
+type CodeBlock struct {
+	Lang string
+	Code string
+}
+
+// Config holds everything needed to construct a Scraper. It exists as a
+// struct (rather than a long constructor parameter list) because the number
+// of crawl-tuning knobs has grown past what's readable positionally.
+type Config struct {
+	BaseURL       string
+	OutputPath    string
+	MinDelay      float64
+	MaxDelay      float64
+	Organization  Organization
+	SinglePage    bool
+	Workers       int
+	Resume        bool
+	DashboardAddr string
+	Extractor     string
+	IgnoreRobots  bool
+	Render        string
+	WaitSelector  string
+	WaitMS        int
+	BrowserPath   string
+	Incremental   bool
+	Formats       string
+	Rules         []FilterRule
+	MaxDepth      int
+	MaxPages      int
 }
 
 type Scraper struct {
-	baseURL      string
-	outputPath   string
-	outputDir    string
-	visitedURLs  map[string]bool
-	client       *http.Client
-	domainPrefix string
-	minDelay     float64
-	maxDelay     float64
-	organization Organization
-	pages        []Page
-	singlePage   bool
+	baseURL       string
+	outputPath    string
+	outputDir     string
+	visitedURLs   map[string]bool
+	client        *http.Client
+	domainPrefix  string
+	minDelay      float64
+	maxDelay      float64
+	organization  Organization
+	pages         []Page
+	pagesMu       sync.Mutex
+	singlePage    bool
+	workers       int
+	resume        bool
+	queuePath     string
+	limiter       *RateLimiter
+	counters      *Counters
+	dashboardAddr string
+	extractorName string
+	ignoreRobots  bool
+	robots        *discovery.Robots
+	fetcher       Fetcher
+	incremental   bool
+	cache         *pageCache
+	emitters      []Emitter
+	urlFilter     *URLFilter
 }
 
-func NewScraper(baseURL, outputPath string, minDelay, maxDelay float64, org Organization, singlePage bool) *Scraper {
+func NewScraper(cfg Config) (*Scraper, error) {
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	outputDir := strings.TrimSuffix(cfg.OutputPath, filepath.Ext(cfg.OutputPath))
+
+	client := &http.Client{
+		Timeout: time.Second * 30,
+	}
+
+	var fetcher Fetcher
+	if strings.ToLower(cfg.Render) == "js" {
+		chromeFetcher, err := newChromedpFetcher(cfg.BrowserPath, cfg.WaitSelector, cfg.WaitMS)
+		if err != nil {
+			return nil, fmt.Errorf("starting headless Chrome: %v", err)
+		}
+		fetcher = chromeFetcher
+	} else {
+		fetcher = newHTTPFetcher(client)
+	}
+
+	emitters, err := resolveEmitters(cfg.Formats, emitterOptions{
+		outputPath:   cfg.OutputPath,
+		outputDir:    outputDir,
+		organization: cfg.Organization,
+		baseURL:      cfg.BaseURL,
+		browserPath:  cfg.BrowserPath,
+		incremental:  cfg.Incremental,
+		singlePage:   cfg.SinglePage,
+	})
+	if err != nil {
+		if closer, ok := fetcher.(interface{ Close() error }); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+
 	return &Scraper{
-		baseURL:     sanitizeURL(baseURL),
-		outputPath:  outputPath,
-		outputDir:   strings.TrimSuffix(outputPath, filepath.Ext(outputPath)),
-		visitedURLs: make(map[string]bool),
-		client: &http.Client{
-			Timeout: time.Second * 30,
-		},
-		domainPrefix: extractDomainPrefix(baseURL),
-		minDelay:     minDelay,
-		maxDelay:     maxDelay,
-		organization: org,
-		pages:        make([]Page, 0),
-		singlePage:   singlePage,
+		baseURL:       sanitizeURL(cfg.BaseURL),
+		outputPath:    cfg.OutputPath,
+		outputDir:     outputDir,
+		visitedURLs:   make(map[string]bool),
+		client:        client,
+		domainPrefix:  extractDomainPrefix(cfg.BaseURL),
+		minDelay:      cfg.MinDelay,
+		maxDelay:      cfg.MaxDelay,
+		organization:  cfg.Organization,
+		pages:         make([]Page, 0),
+		singlePage:    cfg.SinglePage,
+		workers:       workers,
+		resume:        cfg.Resume,
+		queuePath:     filepath.Join(outputDir, ".docscrap-queue.log"),
+		limiter:       NewRateLimiter(cfg.MinDelay, cfg.MaxDelay),
+		counters:      &Counters{},
+		dashboardAddr: cfg.DashboardAddr,
+		extractorName: cfg.Extractor,
+		ignoreRobots:  cfg.IgnoreRobots,
+		robots:        &discovery.Robots{},
+		fetcher:       fetcher,
+		incremental:   cfg.Incremental,
+		cache:         loadPageCache(filepath.Join(outputDir, ".docscrap-cache.json")),
+		emitters:      emitters,
+		urlFilter:     NewURLFilter(cfg.Rules, cfg.MaxDepth, cfg.MaxPages),
+	}, nil
+}
+
+// Close releases resources held by the scraper, such as a headless Chrome
+// instance under -render js. Safe to call even when nothing needs closing.
+func (s *Scraper) Close() error {
+	if closer, ok := s.fetcher.(interface{ Close() error }); ok {
+		return closer.Close()
 	}
+	return nil
 }
 
 func sanitizeURL(rawURL string) string {
@@ -95,16 +225,6 @@ func sanitizeFilename(name string) string {
 	return result
 }
 
-func (s *Scraper) humanizedDelay(noDelay bool) {
-	if noDelay {
-		return
-	}
-	delay := s.minDelay + rand.Float64()*(s.maxDelay-s.minDelay)
-	delayDuration := time.Duration(delay * float64(time.Second))
-	log.Printf("Pausing for %.3f seconds", delay)
-	time.Sleep(delayDuration)
-}
-
 func (s *Scraper) resolveURL(href string) string {
 	if strings.HasPrefix(href, "http") {
 		return href
@@ -141,37 +261,26 @@ func (s *Scraper) shouldProcessURL(urlStr string) bool {
 		return false
 	}
 
-	relPath := strings.TrimPrefix(checkURL.Path, baseURL.Path)
-
-	ignorePaths := []string{
-		"/assets/", "/static/", "/img/", "/images/",
-		"/js/", "/css/", "/fonts/", "/examples/",
-		"/blog/", "/community/", "/download/",
+	if !s.robots.Allowed(checkURL.Path) {
+		log.Printf("Skipping %s: disallowed by %s", urlStr, s.robots)
+		return false
 	}
 
-	for _, ignore := range ignorePaths {
-		if strings.Contains(relPath, ignore) {
-			return false
-		}
+	if !s.urlFilter.Allowed(checkURL.Path) {
+		return false
 	}
 
 	return true
 }
 
 func (s *Scraper) getAllDocLinks(currentURL string) ([]string, error) {
-	req, err := http.NewRequest("GET", currentURL, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0")
-
-	resp, err := s.client.Do(req)
+	body, err := s.fetcher.Fetch(context.Background(), currentURL)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(body)
 	if err != nil {
 		return nil, err
 	}
@@ -190,25 +299,48 @@ func (s *Scraper) getAllDocLinks(currentURL string) ([]string, error) {
 }
 
 func (s *Scraper) scrapePage(url string) (page Page, err error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return Page{}, err
+	var body io.ReadCloser
+	var etag, lastModified string
+
+	if s.incremental {
+		if condFetcher, ok := s.fetcher.(ConditionalFetcher); ok {
+			prior, _ := s.cache.get(url)
+			result, err := condFetcher.FetchConditional(context.Background(), url, prior.ETag, prior.LastModified)
+			if err != nil {
+				return Page{}, err
+			}
+			if result.NotModified {
+				return Page{
+					Title:       prior.Title,
+					URL:         url,
+					Filename:    prior.Filename,
+					Level:       prior.Level,
+					Content:     prior.Content,
+					ContentText: prior.ContentText,
+					Headings:    prior.Headings,
+					CodeBlocks:  prior.CodeBlocks,
+					Changed:     false,
+				}, nil
+			}
+			body, etag, lastModified = result.Body, result.ETag, result.LastModified
+		}
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0")
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return Page{}, err
+	if body == nil {
+		body, err = s.fetcher.Fetch(context.Background(), url)
+		if err != nil {
+			return Page{}, err
+		}
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(body)
 	if err != nil {
 		return Page{}, err
 	}
 
 	doc.Find(`
-		header, footer, nav, 
+		header, footer, nav,
 		.header, .footer, .navigation, .nav, .navbar,
 		.sidebar, .side-bar, .menu, .toc,
 		.ad, .ads, .advertisement,
@@ -218,134 +350,62 @@ func (s *Scraper) scrapePage(url string) (page Page, err error) {
 		.social-links, .share-buttons
 	`).Remove()
 
-	var content strings.Builder
-	content.WriteString(fmt.Sprintf("\n## Source: %s\n\n", url))
-
-	mainContent := doc.Find(`
-		article, 
-		main, 
-		[role="main"],
-		.main-content,
-		.content,
-		.article,
-		.post,
-		.documentation,
-		.doc-content,
-		#content,
-		#main
-	`).First()
-
-	if mainContent.Length() == 0 {
-		mainContent = doc.Find("body")
-	}
-
-	var title string
-	if titleElem := mainContent.Find("h1").First(); titleElem.Length() > 0 {
-		title = strings.TrimSpace(titleElem.Text())
-		content.WriteString(fmt.Sprintf("# %s\n\n", title))
+	extractor := resolveExtractor(s.extractorName, doc)
+	page, err = extractor.Extract(doc, url)
+	if err != nil {
+		return Page{}, err
 	}
 
 	urlPath := strings.Trim(strings.TrimPrefix(url, s.baseURL), "/")
-	level := len(strings.Split(urlPath, "/"))
-
-	mainContent.Find("h2, h3, h4, h5, h6, p, pre, ul, ol, code, blockquote").Each(func(i int, sel *goquery.Selection) {
-		switch goquery.NodeName(sel) {
-		case "h2", "h3", "h4", "h5", "h6":
-			level := int(sel.Get(0).Data[1] - '0')
-			text := strings.TrimSpace(sel.Text())
-			if text != "" {
-				content.WriteString(fmt.Sprintf("%s %s\n\n", strings.Repeat("#", level), text))
-			}
-		case "p":
-			text := strings.TrimSpace(sel.Text())
-			if text != "" {
-				content.WriteString(text + "\n\n")
-			}
-		case "pre":
-			if codeBlock := sel.Find("code"); codeBlock.Length() > 0 {
-				lang := ""
-				if className, exists := codeBlock.Attr("class"); exists {
-					langClasses := []string{"language-", "lang-", "brush:"}
-					for _, prefix := range langClasses {
-						if strings.Contains(className, prefix) {
-							parts := strings.Split(className, prefix)
-							if len(parts) > 1 {
-								lang = strings.Split(parts[1], " ")[0]
-								break
-							}
-						}
-					}
-				}
-				code := strings.TrimSpace(codeBlock.Text())
-				if code != "" {
-					if lang != "" {
-						content.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", lang, code))
-					} else {
-						content.WriteString(fmt.Sprintf("```\n%s\n```\n\n", code))
-					}
-				}
-			}
-		case "ul", "ol":
-			sel.Find("li").Each(func(_ int, li *goquery.Selection) {
-				text := strings.TrimSpace(li.Text())
-				if text != "" {
-					content.WriteString("- " + text + "\n")
-				}
-			})
-			content.WriteString("\n")
-		case "blockquote":
-			text := strings.TrimSpace(sel.Text())
-			if text != "" {
-				for _, line := range strings.Split(text, "\n") {
-					content.WriteString("> " + strings.TrimSpace(line) + "\n")
-				}
-				content.WriteString("\n")
-			}
+	page.Level = len(strings.Split(urlPath, "/"))
+	page.Changed = true
+
+	if s.incremental {
+		hash := hashContent(page.Content)
+		prior, ok := s.cache.get(url)
+		if ok && prior.ContentHash == hash {
+			page.Changed = false
 		}
-	})
-
-	content.WriteString("---\n\n")
-
-	filename := sanitizeFilename(title)
-	if filename == "" {
-		filename = sanitizeFilename(filepath.Base(url))
-	}
-	filename += ".md"
-
-	return Page{
-		Title:    title,
-		Content:  content.String(),
-		URL:      url,
-		Filename: filename,
-		Level:    level,
-	}, nil
+		s.cache.set(url, cacheEntry{
+			ETag:         etag,
+			LastModified: lastModified,
+			ContentHash:  hash,
+			LastFetched:  nowStamp(),
+			Title:        page.Title,
+			Filename:     page.Filename,
+			Level:        page.Level,
+			Content:      page.Content,
+			ContentText:  page.ContentText,
+			Headings:     page.Headings,
+			CodeBlocks:   page.CodeBlocks,
+		})
+	}
+
+	return page, nil
 }
 
-func (s *Scraper) writeContentToFile(filepath string, content string) error {
+// writeMu serializes writes to the shared single-file buffer; writes to
+// distinct per-page files don't need it, but taking it for every write keeps
+// the locking story in one place as more callers show up.
+var writeMu sync.Mutex
+
+func writeContentToFile(filepath string, content string) error {
 	dir := path.Dir(filepath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
+	writeMu.Lock()
+	defer writeMu.Unlock()
 	return os.WriteFile(filepath, []byte(content), 0644)
 }
 
-func (s *Scraper) createIndex() error {
-	indexPath := filepath.Join(s.outputDir, "index.md")
-	var content strings.Builder
-
-	title := strings.TrimPrefix(s.baseURL, "https://")
-	title = strings.TrimPrefix(title, "http://")
-	content.WriteString(fmt.Sprintf("# Documentation: %s\n\n", title))
-	content.WriteString("## Table of Contents\n\n")
-
-	for _, page := range s.pages {
-		indent := strings.Repeat("  ", page.Level-1)
-		content.WriteString(fmt.Sprintf("%s- [%s](%s) - [source](%s)\n",
-			indent, page.Title, page.Filename, page.URL))
-	}
-
-	return s.writeContentToFile(indexPath, content.String())
+// addPage appends a scraped page under pagesMu; pages is shared across all
+// fetch workers.
+func (s *Scraper) addPage(page Page) {
+	s.pagesMu.Lock()
+	s.pages = append(s.pages, page)
+	s.pagesMu.Unlock()
 }
 
 func (s *Scraper) Scrape(noDelay bool) error {
@@ -355,70 +415,155 @@ func (s *Scraper) Scrape(noDelay bool) error {
 			return fmt.Errorf("error scraping page %s: %v", s.baseURL, err)
 		}
 
-		if s.organization == SingleFile {
-			return s.writeContentToFile(s.outputPath, page.Content)
+		if s.incremental {
+			if err := s.cache.save(); err != nil {
+				log.Printf("saving incremental cache: %v", err)
+			}
 		}
 
-		outputPath := filepath.Join(s.outputDir, page.Filename)
-		return s.writeContentToFile(outputPath, page.Content)
+		for _, emitter := range s.emitters {
+			if err := emitter.OpenPage(page); err != nil {
+				return fmt.Errorf("writing page %s: %v", page.URL, err)
+			}
+			if err := emitter.Close(); err != nil {
+				return fmt.Errorf("closing output: %v", err)
+			}
+		}
+		return nil
 	}
 
-	links := []string{s.baseURL}
-	processed := make(map[string]bool)
-	var mainContent strings.Builder
+	if s.dashboardAddr != "" {
+		NewDashboard(s.dashboardAddr, s.counters).Start()
+	}
 
-	for len(links) > 0 {
-		currentURL := links[0]
-		links = links[1:]
+	// Discovery (robots.txt, sitemaps, llms.txt) runs even on -resume: the
+	// robots ruleset and Crawl-delay must apply for the rest of a resumed
+	// crawl too, not just a fresh one. Only the discovered seed URLs are
+	// skipped on resume, since the on-disk queue already has its own
+	// frontier to replay.
+	discovered := discovery.Discover(s.client, s.baseURL, s.ignoreRobots)
+	s.robots = discovered.Robots
+	if s.robots.CrawlDelay > 0 {
+		s.limiter.EnsureMinDelay(s.robots.CrawlDelay)
+	}
 
-		if processed[currentURL] {
-			continue
+	seeds := []QueueItem{{URL: s.baseURL, Level: 0}}
+	if !s.resume {
+		if discovered.PreferSeeds {
+			seeds = nil
+			for _, u := range discovered.SeedURLs {
+				if s.shouldProcessURL(u) {
+					seeds = append(seeds, QueueItem{URL: u, Level: 0})
+				}
+			}
+		} else {
+			for _, u := range discovered.SeedURLs {
+				if u != s.baseURL && s.shouldProcessURL(u) {
+					seeds = append(seeds, QueueItem{URL: u, Level: 1})
+				}
+			}
 		}
+	}
 
-		log.Printf("Scraping: %s", currentURL)
+	queue, err := NewVisitQueue(s.queuePath, s.resume, seeds)
+	if err != nil {
+		return fmt.Errorf("opening visit queue: %v", err)
+	}
+	defer queue.Close()
 
-		page, err := s.scrapePage(currentURL)
-		if err != nil {
-			log.Printf("Error scraping %s: %v", currentURL, err)
-			continue
+	var wg sync.WaitGroup
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			s.runWorker(workerID, queue, noDelay)
+		}(i)
+	}
+	wg.Wait()
+
+	if s.incremental {
+		if err := s.cache.save(); err != nil {
+			log.Printf("saving incremental cache: %v", err)
+		}
+	}
+
+	for _, emitter := range s.emitters {
+		if err := emitter.Close(); err != nil {
+			return fmt.Errorf("closing output: %v", err)
+		}
+	}
+	return nil
+}
+
+// runWorker pulls URLs off the shared visit queue until the frontier is
+// exhausted (pending empty and no sibling worker currently has work
+// in-flight), fetching and handing pages to every configured Emitter as it
+// goes. Workers pause without exiting while the dashboard reports paused.
+func (s *Scraper) runWorker(workerID int, queue *VisitQueue, noDelay bool) {
+	for {
+		for s.counters.Paused() {
+			time.Sleep(250 * time.Millisecond)
 		}
 
-		switch s.organization {
-		case SingleFile:
-			mainContent.WriteString(page.Content)
-		case ByChapters, ByPages:
-			outputPath := filepath.Join(s.outputDir, page.Filename)
-			if err := s.writeContentToFile(outputPath, page.Content); err != nil {
-				log.Printf("Error writing file %s: %v", outputPath, err)
+		if s.urlFilter.PagesExhausted(s.counters.DoneCount()) {
+			return
+		}
+
+		item, ok := queue.Pop()
+		if !ok {
+			if s.counters.InFlightCount() == 0 {
+				return
 			}
+			time.Sleep(100 * time.Millisecond)
+			continue
 		}
 
-		s.pages = append(s.pages, page)
-		processed[currentURL] = true
+		s.counters.incInFlight()
+		s.counters.setQueued(queue.Len())
+
+		s.limiter.Wait(noDelay)
 
-		newLinks, err := s.getAllDocLinks(currentURL)
+		log.Printf("[worker %d] Scraping: %s", workerID, item.URL)
+
+		page, err := s.scrapePage(item.URL)
 		if err != nil {
-			log.Printf("Error getting links from %s: %v", currentURL, err)
+			log.Printf("[worker %d] Error scraping %s: %v", workerID, item.URL, err)
+			s.counters.incFailed()
+			s.counters.decInFlight()
+			queue.MarkDone(item.URL)
 			continue
 		}
 
-		for _, link := range newLinks {
-			if !processed[link] {
-				links = append(links, link)
+		if s.incremental && !page.Changed {
+			log.Printf("[worker %d] Unchanged since last run: %s", workerID, item.URL)
+		}
+		// Every emitter still gets the page even when unchanged - aggregate
+		// formats (single-file Markdown, JSONL, HTML, EPUB, PDF) need every
+		// page to rebuild their output in full; only markdownEmitter's
+		// per-page-file write is skipped internally for unchanged pages.
+		for _, emitter := range s.emitters {
+			if err := emitter.OpenPage(page); err != nil {
+				log.Printf("[worker %d] Error writing page %s: %v", workerID, item.URL, err)
 			}
 		}
 
-		s.humanizedDelay(noDelay)
-	}
+		s.addPage(page)
+		queue.MarkDone(item.URL)
 
-	if s.organization == SingleFile {
-		title := strings.TrimPrefix(s.baseURL, "https://")
-		title = strings.TrimPrefix(title, "http://")
-		header := fmt.Sprintf("# Documentation: %s\n\n", title)
+		newLinks, err := s.getAllDocLinks(item.URL)
+		if err != nil {
+			log.Printf("[worker %d] Error getting links from %s: %v", workerID, item.URL, err)
+		} else if s.urlFilter.DepthAllowed(item.Level + 1) {
+			for _, link := range newLinks {
+				if !queue.IsVisited(link) {
+					queue.Enqueue(QueueItem{URL: link, Level: item.Level + 1})
+				}
+			}
+		}
 
-		return s.writeContentToFile(s.outputPath, header+mainContent.String())
-	} else {
-		return s.createIndex()
+		s.counters.incDone()
+		s.counters.decInFlight()
+		s.counters.setQueued(queue.Len())
 	}
 }
 
@@ -436,8 +581,29 @@ Options:
   -n, --nodelay     Disable delay between requests
   -p, --single-page Scrape only the provided URL without following links
   --org             Organization type: single, chapters, pages [default: single]
+  -workers          Number of concurrent fetch workers [default: 1]
+  -resume           Resume a crawl from its on-disk visit queue
+  -dashboard        Address to serve a status/control dashboard on (e.g. :8080)
+  -extractor        Content extractor: auto, docusaurus, mkdocs, sphinx, gitbook, generic [default: auto]
+  -ignore-robots    Ignore robots.txt rules
+  -render           Fetch mode: http or js (headless Chrome) [default: http]
+  -wait-selector    CSS selector to wait for before reading a JS-rendered page
+  -wait-ms          Milliseconds to wait before reading a JS-rendered page
+  -browser-path     Path to the Chrome/Chromium binary for -render js
+  -incremental      Skip writing pages unchanged since the last run (uses a .docscrap-cache.json sidecar)
+  -format           Comma-separated output formats: md, jsonl, html, epub, pdf [default: md]
+  -include          Glob rule to include matching URL paths (repeatable, last match wins)
+  -exclude          Glob rule to exclude matching URL paths (repeatable, last match wins)
+  -include-regex    Regex rule to include matching URL paths (repeatable, last match wins)
+  -exclude-regex    Regex rule to exclude matching URL paths (repeatable, last match wins)
+  -max-depth        Maximum URL path depth to crawl [default: unlimited]
+  -max-pages        Maximum number of pages to scrape [default: unlimited]
   -h, --help        Display this help message
 
+A .docscraprc file (JSON or YAML) in the working directory can set the same
+include/exclude rules and depth/page caps as a reusable per-site profile;
+-include/-exclude flags on the command line are applied after it.
+
 Organization Types:
   single            Create a single file containing all documentation
   chapters          Split documentation into chapter files
@@ -453,15 +619,29 @@ Examples:
 
 func main() {
 	var (
-		url        string
-		output     string
-		minDelay   float64
-		maxDelay   float64
-		help       bool
-		noDelay    bool
-		organize   string
-		singlePage bool
+		url           string
+		output        string
+		minDelay      float64
+		maxDelay      float64
+		help          bool
+		noDelay       bool
+		organize      string
+		singlePage    bool
+		workers       int
+		resume        bool
+		dashboardAddr string
+		extractorName string
+		ignoreRobots  bool
+		render        string
+		waitSelector  string
+		waitMS        int
+		browserPath   string
+		incremental   bool
+		formats       string
+		maxDepth      int
+		maxPages      int
 	)
+	var cliRules []FilterRule
 
 	flag.StringVar(&url, "u", "", "Documentation URL to scrape")
 	flag.StringVar(&output, "o", "", "Output file path")
@@ -471,6 +651,23 @@ func main() {
 	flag.BoolVar(&noDelay, "n", false, "Disable delay between requests")
 	flag.StringVar(&organize, "org", "single", "Organization type: single, chapters, pages")
 	flag.BoolVar(&singlePage, "p", false, "Scrape only the provided URL without following links (shorthand)")
+	flag.IntVar(&workers, "workers", 1, "Number of concurrent fetch workers")
+	flag.BoolVar(&resume, "resume", false, "Resume a crawl from its on-disk visit queue")
+	flag.StringVar(&dashboardAddr, "dashboard", "", "Address to serve a status/control dashboard on (e.g. :8080)")
+	flag.StringVar(&extractorName, "extractor", "auto", "Content extractor: auto, docusaurus, mkdocs, sphinx, gitbook, generic")
+	flag.BoolVar(&ignoreRobots, "ignore-robots", false, "Ignore robots.txt rules")
+	flag.StringVar(&render, "render", "http", "Fetch mode: http or js (headless Chrome)")
+	flag.StringVar(&waitSelector, "wait-selector", "", "CSS selector to wait for before reading a JS-rendered page")
+	flag.IntVar(&waitMS, "wait-ms", 0, "Milliseconds to wait before reading a JS-rendered page (used if -wait-selector is unset)")
+	flag.StringVar(&browserPath, "browser-path", "", "Path to the Chrome/Chromium binary for -render js")
+	flag.BoolVar(&incremental, "incremental", false, "Skip writing pages unchanged since the last run")
+	flag.StringVar(&formats, "format", "md", "Comma-separated output formats: md, jsonl, html, epub, pdf")
+	flag.Var(&ruleFlag{include: true, rules: &cliRules}, "include", "Glob rule to include matching URL paths (last match wins, e.g. '/docs/**')")
+	flag.Var(&ruleFlag{include: false, rules: &cliRules}, "exclude", "Glob rule to exclude matching URL paths (last match wins, e.g. '**/changelog/**')")
+	flag.Var(&ruleFlag{include: true, regex: true, rules: &cliRules}, "include-regex", "Regex rule to include matching URL paths (last match wins)")
+	flag.Var(&ruleFlag{include: false, regex: true, rules: &cliRules}, "exclude-regex", "Regex rule to exclude matching URL paths (last match wins)")
+	flag.IntVar(&maxDepth, "max-depth", 0, "Maximum URL path depth to crawl (0 = unlimited)")
+	flag.IntVar(&maxPages, "max-pages", 0, "Maximum number of pages to scrape (0 = unlimited)")
 
 	flag.StringVar(&url, "url", "", "Documentation URL to scrape")
 	flag.StringVar(&output, "output", "", "Output file path")
@@ -508,7 +705,50 @@ func main() {
 
 	rand.Seed(time.Now().UnixNano())
 
-	scraper := NewScraper(url, output, minDelay, maxDelay, org, singlePage)
+	rc, err := loadRCFile()
+	if err != nil {
+		log.Fatalf("loading %s: %v", rcFileName, err)
+	}
+	profileRules, err := rc.rules()
+	if err != nil {
+		log.Fatalf("loading %s: %v", rcFileName, err)
+	}
+	rules := append(profileRules, cliRules...)
+
+	if maxDepth == 0 {
+		maxDepth = rc.MaxDepth
+	}
+	if maxPages == 0 {
+		maxPages = rc.MaxPages
+	}
+
+	scraper, err := NewScraper(Config{
+		BaseURL:       url,
+		OutputPath:    output,
+		MinDelay:      minDelay,
+		MaxDelay:      maxDelay,
+		Organization:  org,
+		SinglePage:    singlePage,
+		Workers:       workers,
+		Resume:        resume,
+		DashboardAddr: dashboardAddr,
+		Extractor:     extractorName,
+		IgnoreRobots:  ignoreRobots,
+		Render:        render,
+		WaitSelector:  waitSelector,
+		WaitMS:        waitMS,
+		BrowserPath:   browserPath,
+		Incremental:   incremental,
+		Formats:       formats,
+		Rules:         rules,
+		MaxDepth:      maxDepth,
+		MaxPages:      maxPages,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer scraper.Close()
+
 	if err := scraper.Scrape(noDelay); err != nil {
 		log.Fatal(err)
 	}
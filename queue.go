@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// VisitQueue is an append-only, on-disk work queue of URLs to crawl. Every
+// enqueue/done transition is logged to disk so a crawl can be killed (Ctrl-C,
+// crash) and resumed later with -resume instead of starting over.
+type VisitQueue struct {
+	mu      sync.Mutex
+	pending []QueueItem
+	visited map[string]bool
+	logFile *os.File
+	logPath string
+}
+
+// QueueItem is a single frontier entry: a URL and the crawl depth it was
+// discovered at.
+type QueueItem struct {
+	URL   string
+	Level int
+}
+
+// NewVisitQueue opens (or creates) the on-disk queue log at logPath. When
+// resume is true, the log is replayed first to rebuild pending/visited state
+// from a previous run; otherwise the log is truncated and the crawl starts
+// fresh from seeds.
+func NewVisitQueue(logPath string, resume bool, seeds []QueueItem) (*VisitQueue, error) {
+	q := &VisitQueue{
+		visited: make(map[string]bool),
+		logPath: logPath,
+	}
+
+	if resume {
+		if err := q.replay(); err != nil {
+			return nil, fmt.Errorf("replaying visit queue %s: %v", logPath, err)
+		}
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if !resume {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(logPath, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+	q.logFile = f
+
+	if !resume || (len(q.pending) == 0 && len(q.visited) == 0) {
+		// Either a fresh crawl, or a resume requested with nothing on disk
+		// to resume from; behave like a fresh crawl either way.
+		q.pending = append([]QueueItem(nil), seeds...)
+		for _, seed := range seeds {
+			fmt.Fprintf(q.logFile, "ENQ\t%s\t%d\n", seed.URL, seed.Level)
+		}
+	}
+
+	return q, nil
+}
+
+// replay reconstructs pending/visited state by reading every "ENQ"/"DONE"
+// line previously written to the log.
+func (q *VisitQueue) replay() error {
+	f, err := os.Open(q.logPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enqueued := make(map[string]int)
+	order := make([]string, 0)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) < 2 {
+			continue
+		}
+		switch parts[0] {
+		case "ENQ":
+			if _, seen := enqueued[parts[1]]; !seen {
+				order = append(order, parts[1])
+			}
+			level := 0
+			if len(parts) == 3 {
+				level, _ = strconv.Atoi(parts[2])
+			}
+			enqueued[parts[1]] = level
+		case "DONE":
+			q.visited[parts[1]] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for _, url := range order {
+		if q.visited[url] {
+			continue
+		}
+		q.pending = append(q.pending, QueueItem{URL: url, Level: enqueued[url]})
+	}
+
+	return nil
+}
+
+// Enqueue adds url to the frontier (and durably logs it) unless it has
+// already been visited or is already pending.
+func (q *VisitQueue) Enqueue(item QueueItem) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.visited[item.URL] {
+		return
+	}
+	for _, p := range q.pending {
+		if p.URL == item.URL {
+			return
+		}
+	}
+
+	q.pending = append(q.pending, item)
+	fmt.Fprintf(q.logFile, "ENQ\t%s\t%d\n", item.URL, item.Level)
+}
+
+// Pop removes and returns the next pending item, or ok=false if the frontier
+// is currently empty. The item is immediately marked visited (claimed) so a
+// second worker can't pop or re-enqueue the same URL while the first is
+// still fetching it - two pages discovered concurrently that both link to
+// it would otherwise race past IsVisited/Enqueue's dedup checks and both
+// fetch it. This claim is in-memory only (not logged); if the process dies
+// before MarkDone, replay won't see a DONE line and the URL is retried on
+// resume, same as today.
+func (q *VisitQueue) Pop() (item QueueItem, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 {
+		return QueueItem{}, false
+	}
+	item = q.pending[0]
+	q.pending = q.pending[1:]
+	q.visited[item.URL] = true
+	return item, true
+}
+
+// MarkDone records url as visited and durably logs the completion so a
+// resumed crawl never reprocesses it.
+func (q *VisitQueue) MarkDone(url string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.visited[url] = true
+	fmt.Fprintf(q.logFile, "DONE\t%s\n", url)
+}
+
+// IsVisited reports whether url has already been completed.
+func (q *VisitQueue) IsVisited(url string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.visited[url]
+}
+
+// Len reports the number of items currently pending.
+func (q *VisitQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Close flushes and closes the underlying log file.
+func (q *VisitQueue) Close() error {
+	return q.logFile.Close()
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestVisitQueueResumeReplaysPendingAndVisited(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "queue.log")
+	seeds := []QueueItem{{URL: "https://example.com/", Level: 0}}
+
+	q, err := NewVisitQueue(logPath, false, seeds)
+	if err != nil {
+		t.Fatalf("NewVisitQueue: %v", err)
+	}
+
+	q.Enqueue(QueueItem{URL: "https://example.com/a", Level: 1})
+	q.Enqueue(QueueItem{URL: "https://example.com/b", Level: 1})
+
+	item, ok := q.Pop()
+	if !ok || item.URL != "https://example.com/" {
+		t.Fatalf("Pop() = %+v, %v, want root seed", item, ok)
+	}
+	q.MarkDone(item.URL)
+
+	item, ok = q.Pop()
+	if !ok || item.URL != "https://example.com/a" {
+		t.Fatalf("Pop() = %+v, %v, want /a", item, ok)
+	}
+	// /a is left in flight (no MarkDone) to verify it's retried after resume.
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	resumed, err := NewVisitQueue(logPath, true, nil)
+	if err != nil {
+		t.Fatalf("NewVisitQueue (resume): %v", err)
+	}
+	defer resumed.Close()
+
+	if !resumed.IsVisited("https://example.com/") {
+		t.Error("completed root seed should still be visited after resume")
+	}
+	if resumed.IsVisited("https://example.com/a") {
+		t.Error("an in-flight (not MarkDone) item should not count as visited after resume")
+	}
+
+	var remaining []string
+	for {
+		item, ok := resumed.Pop()
+		if !ok {
+			break
+		}
+		remaining = append(remaining, item.URL)
+	}
+
+	want := map[string]bool{"https://example.com/a": true, "https://example.com/b": true}
+	if len(remaining) != len(want) {
+		t.Fatalf("resumed queue had %v, want exactly %v", remaining, want)
+	}
+	for _, url := range remaining {
+		if !want[url] {
+			t.Errorf("unexpected URL %q in resumed queue", url)
+		}
+	}
+}
+
+func TestVisitQueuePopClaimsVisited(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "queue.log")
+	q, err := NewVisitQueue(logPath, false, []QueueItem{{URL: "https://example.com/", Level: 0}})
+	if err != nil {
+		t.Fatalf("NewVisitQueue: %v", err)
+	}
+	defer q.Close()
+
+	item, ok := q.Pop()
+	if !ok {
+		t.Fatal("Pop() on a fresh queue should return the seed")
+	}
+
+	if !q.IsVisited(item.URL) {
+		t.Error("an item claimed by Pop should be considered visited so a concurrent discovery can't re-enqueue it")
+	}
+
+	q.Enqueue(item)
+	if _, ok := q.Pop(); ok {
+		t.Error("re-enqueuing an in-flight (popped but not yet done) item should be a no-op")
+	}
+}
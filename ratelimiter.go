@@ -0,0 +1,67 @@
+package main
+
+import (
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RateLimiter enforces a single humanized delay between request starts that
+// is shared across every fetch worker, so raising -workers controls
+// concurrency without raising the crawl's overall request rate.
+type RateLimiter struct {
+	mu       sync.Mutex
+	minDelay float64
+	maxDelay float64
+	next     time.Time
+}
+
+// NewRateLimiter creates a limiter that spaces out request starts by a
+// random duration in [minDelay, maxDelay] seconds.
+func NewRateLimiter(minDelay, maxDelay float64) *RateLimiter {
+	return &RateLimiter{minDelay: minDelay, maxDelay: maxDelay}
+}
+
+// EnsureMinDelay raises the limiter's [minDelay, maxDelay] window so it
+// never paces requests faster than delay seconds apart - used to honor a
+// site's robots.txt Crawl-delay, which takes priority over -min/-max when
+// it asks for something slower than what the user configured.
+func (r *RateLimiter) EnsureMinDelay(delay float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if delay <= r.minDelay {
+		return
+	}
+	r.minDelay = delay
+	if r.maxDelay < r.minDelay {
+		r.maxDelay = r.minDelay
+	}
+}
+
+// Wait blocks the calling worker until it is this request's turn, then
+// reserves the next slot. Pass noDelay to disable pacing entirely (e.g. in
+// tests or against a local fixture server).
+func (r *RateLimiter) Wait(noDelay bool) {
+	if noDelay {
+		return
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	wait := time.Duration(0)
+	if r.next.After(now) {
+		wait = r.next.Sub(now)
+	}
+
+	delay := r.minDelay + rand.Float64()*(r.maxDelay-r.minDelay)
+	delayDuration := time.Duration(delay * float64(time.Second))
+	r.next = now.Add(wait).Add(delayDuration)
+	r.mu.Unlock()
+
+	if wait > 0 {
+		log.Printf("Pausing for %.3f seconds", wait.Seconds())
+		time.Sleep(wait)
+	}
+}
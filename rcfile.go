@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// rcFileName is the profile file loadRCFile looks for in the working
+// directory, letting users commit per-site include/exclude rules instead of
+// repeating a long list of -include/-exclude flags.
+const rcFileName = ".docscraprc"
+
+// loadRCFile reads rcFileName from the working directory, if present. A
+// missing file is not an error - most runs have no profile. The file may be
+// either JSON or YAML; JSON is tried first since it's a subset of YAML and
+// unambiguous when present.
+func loadRCFile() (rcFile, error) {
+	data, err := os.ReadFile(rcFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return rcFile{}, nil
+		}
+		return rcFile{}, err
+	}
+
+	var rc rcFile
+	if jsonErr := json.Unmarshal(data, &rc); jsonErr == nil {
+		return rc, nil
+	}
+	if yamlErr := yaml.Unmarshal(data, &rc); yamlErr != nil {
+		return rcFile{}, fmt.Errorf("parsing %s as JSON or YAML: %v", rcFileName, yamlErr)
+	}
+	return rc, nil
+}
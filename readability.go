@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// GenericExtractor is the fallback used when no known doc-site generator is
+// detected. It's a small port of the Readability density-scoring
+// heuristic: every candidate block is scored by how much of its text isn't
+// just link text, with a handful of tag/id/class boosts and penalties
+// layered on top, and the highest scoring subtree wins.
+type GenericExtractor struct{}
+
+func (GenericExtractor) Extract(doc *goquery.Document, url string) (Page, error) {
+	container := findMainContentByDensity(doc)
+	return renderPage(container, url), nil
+}
+
+const (
+	contentBoost  = 25.0
+	chromePenalty = 25.0
+)
+
+var contentHints = []string{"content", "article", "main", "body", "post", "doc"}
+var chromeHints = []string{"nav", "footer", "aside", "sidebar", "menu", "comment", "related"}
+
+// findMainContentByDensity scores every candidate block in the document and
+// returns the highest scoring one. It falls back to <body> when nothing
+// scores above zero, i.e. when the page is mostly navigation and links.
+func findMainContentByDensity(doc *goquery.Document) *goquery.Selection {
+	var best *goquery.Selection
+	bestScore := 0.0
+
+	doc.Find("div, section, article, main").Each(func(_ int, sel *goquery.Selection) {
+		score := blockDensityScore(sel)
+		if best == nil || score > bestScore {
+			best = sel
+			bestScore = score
+		}
+	})
+
+	if best == nil || bestScore <= 0 {
+		return doc.Find("body")
+	}
+	return best
+}
+
+// blockDensityScore implements: score = text_length - 2*link_text_length,
+// then boosts likely-content elements and penalizes likely-chrome ones.
+func blockDensityScore(sel *goquery.Selection) float64 {
+	text := strings.TrimSpace(sel.Text())
+	textLen := float64(len([]rune(text)))
+	if textLen == 0 {
+		return 0
+	}
+
+	var linkTextLen float64
+	sel.Find("a").Each(func(_ int, a *goquery.Selection) {
+		linkTextLen += float64(len([]rune(strings.TrimSpace(a.Text()))))
+	})
+
+	score := textLen - 2*linkTextLen
+
+	tag := goquery.NodeName(sel)
+	id, _ := sel.Attr("id")
+	class, _ := sel.Attr("class")
+	idClass := strings.ToLower(id + " " + class)
+
+	if tag == "article" || tag == "main" {
+		score += contentBoost
+	}
+	for _, hint := range contentHints {
+		if strings.Contains(idClass, hint) {
+			score += contentBoost
+			break
+		}
+	}
+	for _, hint := range chromeHints {
+		if strings.Contains(idClass, hint) {
+			score -= chromePenalty
+			break
+		}
+	}
+
+	return score
+}